@@ -0,0 +1,56 @@
+package api
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/theskyinflames/rag-example/vecmath"
+)
+
+// corpusDoc is a single embedded chunk held by the server's in-memory
+// corpus.
+type corpusDoc struct {
+	Text      string
+	Embedding []float32
+
+	// Metadata carries loader-supplied provenance (page number, heading
+	// path, ...) through to query results.
+	Metadata map[string]string
+}
+
+// corpus is a concurrency-safe, in-memory vector store for the HTTP
+// server. It intentionally mirrors the CLI's vectorStore rather than
+// importing it, since that type lives in package main.
+type corpus struct {
+	mu   sync.RWMutex
+	docs []corpusDoc
+}
+
+func (c *corpus) add(doc corpusDoc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.docs = append(c.docs, doc)
+}
+
+func (c *corpus) retrieveTopK(queryVec []float32, k int) []corpusDoc {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	type scored struct {
+		doc   corpusDoc
+		score float32
+	}
+	scoredDocs := make([]scored, len(c.docs))
+	for i, doc := range c.docs {
+		scoredDocs[i] = scored{doc: doc, score: vecmath.CosineSim(queryVec, doc.Embedding)}
+	}
+	sort.Slice(scoredDocs, func(i, j int) bool {
+		return scoredDocs[i].score > scoredDocs[j].score
+	})
+
+	var top []corpusDoc
+	for i := 0; i < k && i < len(scoredDocs); i++ {
+		top = append(top, scoredDocs[i].doc)
+	}
+	return top
+}
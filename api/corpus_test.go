@@ -0,0 +1,18 @@
+package api
+
+import "testing"
+
+func TestCorpus_RetrieveTopK_Order(t *testing.T) {
+	c := &corpus{}
+	c.add(corpusDoc{Text: "perfect match", Embedding: []float32{1.0, 0.0}})
+	c.add(corpusDoc{Text: "orthogonal", Embedding: []float32{0.0, 1.0}})
+	c.add(corpusDoc{Text: "partial match", Embedding: []float32{0.5, 0.5}})
+
+	results := c.retrieveTopK([]float32{1.0, 0.0}, 3)
+	expected := []string{"perfect match", "partial match", "orthogonal"}
+	for i, doc := range results {
+		if doc.Text != expected[i] {
+			t.Errorf("result %d = %q, want %q", i, doc.Text, expected[i])
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+// embedPool bounds how many embedding requests are in flight at once, so
+// concurrent /ingest and /query traffic doesn't blow through OpenAI's
+// rate limits.
+type embedPool struct {
+	client      *openai.Client
+	concurrency chan struct{}
+}
+
+func newEmbedPool(client *openai.Client, concurrency int) *embedPool {
+	return &embedPool{
+		client:      client,
+		concurrency: make(chan struct{}, concurrency),
+	}
+}
+
+// embed requests an embedding for text, blocking until a pool slot is
+// free. It propagates ctx into the underlying API call so a canceled
+// request doesn't keep holding a slot.
+func (p *embedPool) embed(ctx context.Context, text string) ([]float32, error) {
+	select {
+	case p.concurrency <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-p.concurrency }()
+
+	resp, err := p.client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+		Model: openai.AdaEmbeddingV2,
+		Input: []string{text},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data[0].Embedding, nil
+}
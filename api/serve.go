@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// shutdownTimeout bounds how long Serve waits for in-flight requests
+// (including open SSE streams) to finish once ctx is canceled.
+const shutdownTimeout = 10 * time.Second
+
+// Serve runs handler over HTTP/2 on addr. With certFile and keyFile both
+// empty it serves h2c (cleartext HTTP/2), which is convenient for local
+// development and lets multiple concurrent /stream SSE connections share a
+// single TCP connection; with both set it serves HTTP/2 over TLS. Serve
+// blocks until ctx is canceled, then shuts down gracefully and returns.
+func Serve(ctx context.Context, addr string, handler http.Handler, certFile, keyFile string) error {
+	h2s := &http2.Server{}
+
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: handler,
+	}
+
+	useTLS := certFile != "" && keyFile != ""
+	if useTLS {
+		if err := http2.ConfigureServer(srv, h2s); err != nil {
+			return err
+		}
+	} else {
+		srv.Handler = h2c.NewHandler(handler, h2s)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		var err error
+		if useTLS {
+			err = srv.ListenAndServeTLS(certFile, keyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+		return <-errCh
+	case err := <-errCh:
+		return err
+	}
+}
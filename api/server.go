@@ -0,0 +1,273 @@
+// Package api exposes the RAG pipeline over HTTP: multipart ingestion, a
+// synchronous query endpoint, and a streaming chat endpoint over
+// Server-Sent Events.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+
+	"github.com/theskyinflames/rag-example/chunk"
+	"github.com/theskyinflames/rag-example/ingest"
+)
+
+const defaultEmbedConcurrency = 4
+
+// Server wires the RAG pipeline (ingest -> embed -> retrieve -> chat) to
+// HTTP handlers.
+type Server struct {
+	client    *openai.Client
+	corpus    *corpus
+	embedPool *embedPool
+	chunker   chunk.Chunker
+	mux       *http.ServeMux
+}
+
+// NewServer builds a Server with its routes registered.
+func NewServer(client *openai.Client) *Server {
+	s := &Server{
+		client:    client,
+		corpus:    &corpus{},
+		embedPool: newEmbedPool(client, defaultEmbedConcurrency),
+		chunker:   chunk.NewSentenceChunker(chunk.DefaultTargetTokens, chunk.DefaultOverlap),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ingest", s.handleIngest)
+	mux.HandleFunc("/query", s.handleQuery)
+	mux.HandleFunc("/stream", s.handleStream)
+	s.mux = mux
+
+	return s
+}
+
+// Handler returns the server's http.Handler, ready to be wrapped by Serve
+// (or any other HTTP/1.1 or HTTP/2 transport).
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// handleIngest accepts a multipart upload, dispatches each file to the
+// ingest registry's loader for its extension, chunks and embeds the
+// resulting documents, and adds them to the in-memory corpus.
+func (s *Server) handleIngest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	var chunksIngested int
+	for _, headers := range r.MultipartForm.File {
+		for _, header := range headers {
+			n, err := s.ingestFile(ctx, header)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("%s: %v", header.Filename, err), http.StatusBadRequest)
+				return
+			}
+			chunksIngested += n
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"chunks_ingested": chunksIngested})
+}
+
+func (s *Server) ingestFile(ctx context.Context, header *multipart.FileHeader) (int, error) {
+	loader, mediatype, err := ingest.ForPath(header.Filename)
+	if err != nil {
+		return 0, err
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	docs, err := loader.Load(ctx, file, mediatype)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, doc := range docs {
+		for _, text := range s.chunker.Chunk(doc.Text) {
+			vec, err := s.embedPool.embed(ctx, text)
+			if err != nil {
+				return count, err
+			}
+			s.corpus.add(corpusDoc{Text: text, Embedding: vec, Metadata: doc.Metadata})
+			count++
+		}
+	}
+	return count, nil
+}
+
+type queryRequest struct {
+	Query string `json:"query"`
+	K     int    `json:"k"`
+	Model string `json:"model"`
+}
+
+type retrievedChunk struct {
+	Text     string            `json:"text"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type queryResponse struct {
+	Chunks []retrievedChunk `json:"chunks"`
+	Answer string           `json:"answer"`
+}
+
+// handleQuery retrieves the top-k chunks for req.Query and asks req.Model
+// to answer using them as context.
+func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req queryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.K <= 0 {
+		req.K = 3
+	}
+	if req.Model == "" {
+		req.Model = openai.GPT4o
+	}
+
+	ctx := r.Context()
+	qVec, err := s.embedPool.embed(ctx, req.Query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	retrieved := s.corpus.retrieveTopK(qVec, req.K)
+	chunks := make([]retrievedChunk, len(retrieved))
+	var contextBuilder strings.Builder
+	for i, doc := range retrieved {
+		chunks[i] = retrievedChunk{Text: doc.Text, Metadata: doc.Metadata}
+		contextBuilder.WriteString(doc.Text)
+		contextBuilder.WriteString("\n")
+	}
+
+	resp, err := s.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model: req.Model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: "user", Content: answerPrompt(contextBuilder.String(), req.Query)},
+		},
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, queryResponse{Chunks: chunks, Answer: resp.Choices[0].Message.Content})
+}
+
+// handleStream retrieves context for the query parameter and streams the
+// model's answer back as Server-Sent Events, one "data:" line per token.
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	if query == "" {
+		http.Error(w, "missing query parameter", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ctx := r.Context()
+	qVec, err := s.embedPool.embed(ctx, query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	retrieved := s.corpus.retrieveTopK(qVec, 3)
+	var contextBuilder strings.Builder
+	for _, doc := range retrieved {
+		contextBuilder.WriteString(doc.Text)
+		contextBuilder.WriteString("\n")
+	}
+
+	stream, err := s.client.CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model: openai.GPT4o,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: "user", Content: answerPrompt(contextBuilder.String(), query)},
+		},
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer stream.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			fmt.Fprint(w, "event: done\ndata: {}\n\n")
+			flusher.Flush()
+			return
+		}
+		if err != nil {
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", jsonString(err.Error()))
+			flusher.Flush()
+			return
+		}
+		if len(resp.Choices) == 0 {
+			continue
+		}
+		if token := resp.Choices[0].Delta.Content; token != "" {
+			fmt.Fprintf(w, "data: %s\n\n", jsonString(token))
+			flusher.Flush()
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func answerPrompt(contextText, query string) string {
+	return fmt.Sprintf("Use the context below to answer the question.\n\nContext:\n%s\n\nQuestion: %s", contextText, query)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// jsonString encodes s as a JSON string literal, for embedding inside an
+// SSE "data:" line.
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,67 @@
+// Package chunk splits ingested text into model-sized pieces. Unlike a
+// fixed character budget, these chunkers respect sentence and document
+// structure so a chunk boundary doesn't land mid-sentence or cross a
+// heading.
+package chunk
+
+import "strings"
+
+// DefaultTargetTokens and DefaultOverlap are the chunk size and overlap
+// the CLI and API server use by default.
+const (
+	DefaultTargetTokens = 300
+	DefaultOverlap      = 50
+)
+
+// Chunker splits text into chunks sized for embedding and retrieval.
+type Chunker interface {
+	Chunk(text string) []string
+}
+
+// packSentences greedily packs sentences into chunks, closing a chunk once
+// its size (as measured by tokenCount) reaches or passes target, so a chunk
+// can run past target by up to one sentence. The last `overlap` sentences
+// are repeated at the head of the next chunk for context continuity across
+// the boundary, dropping the oldest of them while they alone would reach
+// target.
+func packSentences(sentences []string, target, overlap int, tokenCount func(string) int) []string {
+	if len(sentences) == 0 {
+		return nil
+	}
+
+	var chunks []string
+	var current []string
+	tokens := 0
+
+	flush := func() {
+		if len(current) > 0 {
+			chunks = append(chunks, strings.Join(current, " "))
+		}
+	}
+
+	for _, s := range sentences {
+		n := tokenCount(s)
+		if tokens > 0 && tokens >= target {
+			flush()
+
+			start := len(current) - overlap
+			if start < 0 {
+				start = 0
+			}
+			current = append([]string(nil), current[start:]...)
+
+			tokens = 0
+			for _, kept := range current {
+				tokens += tokenCount(kept)
+			}
+			for len(current) > 0 && tokens >= target {
+				tokens -= tokenCount(current[0])
+				current = current[1:]
+			}
+		}
+		current = append(current, s)
+		tokens += n
+	}
+	flush()
+	return chunks
+}
@@ -0,0 +1,102 @@
+package chunk
+
+import (
+	"strings"
+	"unicode"
+)
+
+// abbreviations are common trailing tokens whose period shouldn't be
+// treated as a sentence terminator.
+var abbreviations = map[string]struct{}{
+	"mr.": {}, "mrs.": {}, "ms.": {}, "dr.": {}, "prof.": {}, "sr.": {}, "jr.": {},
+	"vs.": {}, "etc.": {}, "e.g.": {}, "i.e.": {}, "st.": {}, "no.": {}, "fig.": {},
+}
+
+// SentenceChunker segments text on sentence terminators (. ! ?) and packs
+// sentences greedily until a chunk reaches TargetTokens, repeating the last
+// Overlap sentences at the head of the next chunk.
+type SentenceChunker struct {
+	TargetTokens int
+	Overlap      int
+}
+
+// NewSentenceChunker builds a SentenceChunker, defaulting non-positive
+// targetTokens to DefaultTargetTokens and negative overlap to 0.
+func NewSentenceChunker(targetTokens, overlap int) *SentenceChunker {
+	if targetTokens <= 0 {
+		targetTokens = DefaultTargetTokens
+	}
+	if overlap < 0 {
+		overlap = 0
+	}
+	return &SentenceChunker{TargetTokens: targetTokens, Overlap: overlap}
+}
+
+// Chunk implements Chunker.
+func (c *SentenceChunker) Chunk(text string) []string {
+	return packSentences(splitSentences(text), c.TargetTokens, c.Overlap, countWords)
+}
+
+// countWords approximates a token count by whitespace-separated words.
+// TokenChunker should be preferred when an exact token budget matters.
+func countWords(s string) int {
+	return len(strings.Fields(s))
+}
+
+// splitSentences segments text on '.', '!' and '?', treating a run of
+// closing quotes/parens right after the terminator as part of the same
+// sentence, and refusing to split after a known abbreviation.
+func splitSentences(text string) []string {
+	runes := []rune(text)
+
+	var sentences []string
+	var buf strings.Builder
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		buf.WriteRune(r)
+
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+
+		j := i + 1
+		for j < len(runes) && isClosingQuoteOrParen(runes[j]) {
+			buf.WriteRune(runes[j])
+			j++
+		}
+
+		atEnd := j >= len(runes)
+		followedByWhitespace := !atEnd && unicode.IsSpace(runes[j])
+		if (atEnd || followedByWhitespace) && !endsWithAbbreviation(buf.String()) {
+			if s := strings.TrimSpace(buf.String()); s != "" {
+				sentences = append(sentences, s)
+			}
+			buf.Reset()
+		}
+		i = j - 1
+	}
+
+	if s := strings.TrimSpace(buf.String()); s != "" {
+		sentences = append(sentences, s)
+	}
+	return sentences
+}
+
+func isClosingQuoteOrParen(r rune) bool {
+	switch r {
+	case '"', '\'', ')', '”', '’':
+		return true
+	default:
+		return false
+	}
+}
+
+func endsWithAbbreviation(s string) bool {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return false
+	}
+	_, ok := abbreviations[strings.ToLower(fields[len(fields)-1])]
+	return ok
+}
@@ -0,0 +1,94 @@
+package chunk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "simple sentences",
+			text:     "Hello world. How are you? I'm fine!",
+			expected: []string{"Hello world.", "How are you?", "I'm fine!"},
+		},
+		{
+			name:     "respects common abbreviations",
+			text:     "Dr. Smith went home. He was tired.",
+			expected: []string{"Dr. Smith went home.", "He was tired."},
+		},
+		{
+			name:     "keeps trailing quote with its sentence",
+			text:     `She said "hello." Then she left.`,
+			expected: []string{`She said "hello."`, "Then she left."},
+		},
+		{
+			name:     "no terminal punctuation",
+			text:     "just one fragment",
+			expected: []string{"just one fragment"},
+		},
+		{
+			name:     "empty text",
+			text:     "",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitSentences(tt.text)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("splitSentences(%q) = %v, want %v", tt.text, got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("sentence %d = %q, want %q", i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSentenceChunker_ClosesChunkOnceBudgetReached(t *testing.T) {
+	c := NewSentenceChunker(5, 0)
+	text := "One two three. Four five six. Seven eight nine."
+
+	chunks := c.Chunk(text)
+	if len(chunks) != 2 {
+		t.Fatalf("Chunk() returned %d chunks, want 2: %v", len(chunks), chunks)
+	}
+	if chunks[0] != "One two three. Four five six." {
+		t.Errorf("chunk 0 = %q", chunks[0])
+	}
+	if chunks[1] != "Seven eight nine." {
+		t.Errorf("chunk 1 = %q", chunks[1])
+	}
+}
+
+func TestSentenceChunker_Overlap(t *testing.T) {
+	c := NewSentenceChunker(4, 1)
+	text := "One two. Three four. Five six. Seven eight."
+
+	chunks := c.Chunk(text)
+	if len(chunks) < 2 {
+		t.Fatalf("Chunk() returned %d chunks, want at least 2: %v", len(chunks), chunks)
+	}
+	// The sentence that closed the first chunk should reappear at the
+	// head of the second.
+	firstChunkSentences := splitSentences(chunks[0])
+	lastOfFirst := firstChunkSentences[len(firstChunkSentences)-1]
+	if !strings.HasPrefix(chunks[1], lastOfFirst) {
+		t.Errorf("chunk 1 = %q, want it to start with overlapping sentence %q", chunks[1], lastOfFirst)
+	}
+}
+
+func TestSentenceChunker_EmptyText(t *testing.T) {
+	c := NewSentenceChunker(DefaultTargetTokens, DefaultOverlap)
+	if got := c.Chunk(""); got != nil {
+		t.Errorf("Chunk(\"\") = %v, want nil", got)
+	}
+}
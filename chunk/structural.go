@@ -0,0 +1,68 @@
+package chunk
+
+import "strings"
+
+// StructuralChunker splits text on ATX-style heading lines (1-6 "#"
+// characters followed by a space, as in raw Markdown) and delegates each
+// section to Inner, so a chunk never crosses an H1/H2 boundary even when a
+// whole document arrives as one string. MarkdownLoader and HTMLLoader
+// already emit one Document per heading section, with the heading moved
+// into heading_path metadata, so their output holds no such lines.
+type StructuralChunker struct {
+	Inner Chunker
+}
+
+// NewStructuralChunker builds a StructuralChunker. A nil inner defaults
+// to a SentenceChunker with the package's default budget and overlap.
+func NewStructuralChunker(inner Chunker) *StructuralChunker {
+	if inner == nil {
+		inner = NewSentenceChunker(DefaultTargetTokens, DefaultOverlap)
+	}
+	return &StructuralChunker{Inner: inner}
+}
+
+// Chunk implements Chunker.
+func (c *StructuralChunker) Chunk(text string) []string {
+	var chunks []string
+	for _, section := range splitSections(text) {
+		chunks = append(chunks, c.Inner.Chunk(section)...)
+	}
+	return chunks
+}
+
+// splitSections breaks text into pieces at each heading line, keeping the
+// heading with the section that follows it.
+func splitSections(text string) []string {
+	lines := strings.Split(text, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		// strings.Split leaves a trailing empty element when text ends in
+		// "\n"; drop it so the final section doesn't gain a doubled newline.
+		lines = lines[:len(lines)-1]
+	}
+
+	var sections []string
+	var buf strings.Builder
+	for _, line := range lines {
+		if isHeadingLine(line) && buf.Len() > 0 {
+			sections = append(sections, buf.String())
+			buf.Reset()
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	if strings.TrimSpace(buf.String()) != "" {
+		sections = append(sections, buf.String())
+	}
+	return sections
+}
+
+// isHeadingLine recognizes ATX-style headings ("# Title" .. "###### Title").
+func isHeadingLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	return level > 0 && level <= 6 && level < len(trimmed) && trimmed[level] == ' '
+}
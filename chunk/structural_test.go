@@ -0,0 +1,59 @@
+package chunk
+
+import "testing"
+
+func TestIsHeadingLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"# Title", true},
+		{"## Subtitle", true},
+		{"###### Deep", true},
+		{"#NotAHeading", false},
+		{"####### TooDeep", false},
+		{"plain text", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isHeadingLine(tt.line); got != tt.want {
+			t.Errorf("isHeadingLine(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestSplitSections(t *testing.T) {
+	text := "# Intro\nHello there.\n## Details\nMore text here.\n"
+
+	sections := splitSections(text)
+	if len(sections) != 2 {
+		t.Fatalf("splitSections() returned %d sections, want 2: %v", len(sections), sections)
+	}
+	if sections[0] != "# Intro\nHello there.\n" {
+		t.Errorf("section 0 = %q", sections[0])
+	}
+	if sections[1] != "## Details\nMore text here.\n" {
+		t.Errorf("section 1 = %q", sections[1])
+	}
+}
+
+func TestStructuralChunker_SplitsOnHeadings(t *testing.T) {
+	c := NewStructuralChunker(NewSentenceChunker(DefaultTargetTokens, 0))
+	text := "# One\nShort section.\n# Two\nAnother short section.\n"
+
+	chunks := c.Chunk(text)
+	if len(chunks) != 2 {
+		t.Fatalf("Chunk() returned %d chunks, want 2: %v", len(chunks), chunks)
+	}
+}
+
+func TestStructuralChunker_NilInnerDefaultsToSentenceChunker(t *testing.T) {
+	c := NewStructuralChunker(nil)
+	if c.Inner == nil {
+		t.Fatal("NewStructuralChunker(nil) left Inner nil")
+	}
+	if _, ok := c.Inner.(*SentenceChunker); !ok {
+		t.Errorf("NewStructuralChunker(nil).Inner = %T, want *SentenceChunker", c.Inner)
+	}
+}
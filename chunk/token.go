@@ -0,0 +1,47 @@
+package chunk
+
+import (
+	"fmt"
+
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// TokenChunker packs sentences into chunks sized by real BPE token
+// counts via tiktoken-go, rather than characters or whitespace-separated
+// words, since embedding cost and context windows are token-bounded.
+type TokenChunker struct {
+	TargetTokens int
+	Overlap      int
+
+	enc *tiktoken.Tiktoken
+}
+
+// NewTokenChunker builds a TokenChunker using the named tiktoken encoding
+// (e.g. "cl100k_base", the encoding used by text-embedding-ada-002 and
+// GPT-4). encoding defaults to "cl100k_base" when empty.
+func NewTokenChunker(encoding string, targetTokens, overlap int) (*TokenChunker, error) {
+	if encoding == "" {
+		encoding = "cl100k_base"
+	}
+	if targetTokens <= 0 {
+		targetTokens = DefaultTargetTokens
+	}
+	if overlap < 0 {
+		overlap = 0
+	}
+
+	enc, err := tiktoken.GetEncoding(encoding)
+	if err != nil {
+		return nil, fmt.Errorf("chunk: load tiktoken encoding %q: %w", encoding, err)
+	}
+	return &TokenChunker{TargetTokens: targetTokens, Overlap: overlap, enc: enc}, nil
+}
+
+// Chunk implements Chunker.
+func (c *TokenChunker) Chunk(text string) []string {
+	return packSentences(splitSentences(text), c.TargetTokens, c.Overlap, c.countTokens)
+}
+
+func (c *TokenChunker) countTokens(s string) int {
+	return len(c.enc.Encode(s, nil, nil))
+}
@@ -0,0 +1,34 @@
+package chunk
+
+import "testing"
+
+func TestTokenChunker_Chunk(t *testing.T) {
+	c, err := NewTokenChunker("", 6, 0)
+	if err != nil {
+		t.Skip("tiktoken encoding unavailable in this environment:", err)
+	}
+
+	text := "One two three. Four five six. Seven eight nine."
+	chunks := c.Chunk(text)
+	if len(chunks) == 0 {
+		t.Fatal("Chunk() returned no chunks")
+	}
+	for _, chunk := range chunks {
+		if n := c.countTokens(chunk); n > c.TargetTokens*2 {
+			t.Errorf("chunk %q has %d tokens, want roughly <= %d", chunk, n, c.TargetTokens)
+		}
+	}
+}
+
+func TestNewTokenChunker_Defaults(t *testing.T) {
+	c, err := NewTokenChunker("", 0, -1)
+	if err != nil {
+		t.Skip("tiktoken encoding unavailable in this environment:", err)
+	}
+	if c.TargetTokens != DefaultTargetTokens {
+		t.Errorf("TargetTokens = %d, want %d", c.TargetTokens, DefaultTargetTokens)
+	}
+	if c.Overlap != 0 {
+		t.Errorf("Overlap = %d, want 0", c.Overlap)
+	}
+}
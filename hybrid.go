@@ -0,0 +1,203 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/theskyinflames/rag-example/vecmath"
+)
+
+// HybridOpts configures how retrieveHybrid blends lexical (BM25) and vector
+// (cosine) scores.
+type HybridOpts struct {
+	// UseRRF selects Reciprocal Rank Fusion instead of a weighted linear
+	// blend for combining the two rankings.
+	UseRRF bool
+	// RRFK is the RRF rank-damping constant from the standard formula
+	// (score = sum 1/(k+rank)). A zero value defaults to 60.
+	RRFK int
+	// Alpha weights the linear blend between normalized cosine similarity
+	// and normalized BM25, in the range [0, 1]: score = alpha*cos +
+	// (1-alpha)*bm25. Ignored when UseRRF is true. A zero value defaults
+	// to 0.5.
+	Alpha float32
+}
+
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	defaultRRFK  = 60
+	defaultAlpha = 0.5
+)
+
+// englishStopwords holds a small, common English stopword list used to keep
+// the lexical index focused on discriminative terms.
+var englishStopwords = map[string]struct{}{
+	"a": {}, "an": {}, "and": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {},
+	"for": {}, "from": {}, "has": {}, "he": {}, "in": {}, "is": {}, "it": {}, "its": {},
+	"of": {}, "on": {}, "or": {}, "that": {}, "the": {}, "this": {}, "to": {}, "was": {},
+	"were": {}, "will": {}, "with": {},
+}
+
+// tokenize lowercases text, strips punctuation and splits on whitespace,
+// dropping common English stopwords. It is used both at add() time to build
+// the inverted index and at query time to score BM25.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if _, stop := englishStopwords[f]; stop {
+			continue
+		}
+		terms = append(terms, f)
+	}
+	return terms
+}
+
+// bm25Score computes the BM25 score of the document at docIdx against the
+// given (already tokenized) query terms.
+func (vs *vectorStore) bm25Score(queryTerms []string, docIdx int) float32 {
+	n := len(vs.documents)
+	if n == 0 {
+		return 0
+	}
+	avgdl := float32(vs.totalTerms) / float32(n)
+	if avgdl == 0 {
+		return 0
+	}
+
+	dl := float32(vs.docLengths[docIdx])
+	var score float32
+	for _, t := range queryTerms {
+		df := vs.docFreq[t]
+		if df == 0 {
+			continue
+		}
+		tf := float32(vs.termFreqs[docIdx][t])
+		if tf == 0 {
+			continue
+		}
+		idf := float32(math.Log((float64(n)-float64(df)+0.5)/(float64(df)+0.5) + 1))
+		score += idf * (tf * (bm25K1 + 1)) / (tf + bm25K1*(1-bm25B+bm25B*dl/avgdl))
+	}
+	return score
+}
+
+// retrieveHybrid ranks documents by combining cosine similarity over
+// queryVec with a BM25 score over query, fusing the two rankings per opts.
+// The pure-vector path (retrieveTopK) is left untouched for callers that
+// don't need lexical matching.
+func (vs *vectorStore) retrieveHybrid(query string, queryVec []float32, k int, opts HybridOpts) []document {
+	n := len(vs.documents)
+	if n == 0 || k <= 0 {
+		return nil
+	}
+
+	queryTerms := tokenize(query)
+	cosScores := make([]float32, n)
+	bm25Scores := make([]float32, n)
+	for i, doc := range vs.documents {
+		cosScores[i] = vecmath.CosineSim(queryVec, doc.embedding)
+		bm25Scores[i] = vs.bm25Score(queryTerms, i)
+	}
+
+	var fused []float32
+	if opts.UseRRF {
+		fused = fuseRRF(cosScores, bm25Scores, opts.RRFK)
+	} else {
+		fused = fuseLinear(cosScores, bm25Scores, opts.Alpha)
+	}
+
+	idxs := make([]int, n)
+	for i := range idxs {
+		idxs[i] = i
+	}
+	sort.Slice(idxs, func(i, j int) bool {
+		return fused[idxs[i]] > fused[idxs[j]]
+	})
+
+	var top []document
+	for i := 0; i < k && i < n; i++ {
+		top = append(top, vs.documents[idxs[i]])
+	}
+	return top
+}
+
+// fuseRRF combines two rankings via Reciprocal Rank Fusion: score =
+// 1/(k+rank_cos) + 1/(k+rank_bm25), where ranks are 1-based positions in
+// each score's descending order.
+func fuseRRF(cosScores, bm25Scores []float32, k int) []float32 {
+	if k <= 0 {
+		k = defaultRRFK
+	}
+
+	fused := make([]float32, len(cosScores))
+	for _, scores := range [][]float32{cosScores, bm25Scores} {
+		for rank, idx := range rankDesc(scores) {
+			fused[idx] += 1 / float32(k+rank+1)
+		}
+	}
+	return fused
+}
+
+// fuseLinear combines two rankings as alpha*normalizedCos +
+// (1-alpha)*normalizedBM25, min-max normalizing each score set first so
+// they're comparable regardless of scale.
+func fuseLinear(cosScores, bm25Scores []float32, alpha float32) []float32 {
+	if alpha == 0 {
+		alpha = defaultAlpha
+	}
+
+	normCos := minMaxNormalize(cosScores)
+	normBM25 := minMaxNormalize(bm25Scores)
+
+	fused := make([]float32, len(cosScores))
+	for i := range fused {
+		fused[i] = alpha*normCos[i] + (1-alpha)*normBM25[i]
+	}
+	return fused
+}
+
+// minMaxNormalize rescales scores into [0, 1]. A constant input (max==min)
+// normalizes to all zeros rather than dividing by zero.
+func minMaxNormalize(scores []float32) []float32 {
+	if len(scores) == 0 {
+		return nil
+	}
+	min, max := scores[0], scores[0]
+	for _, s := range scores {
+		if s < min {
+			min = s
+		}
+		if s > max {
+			max = s
+		}
+	}
+
+	out := make([]float32, len(scores))
+	if max == min {
+		return out
+	}
+	for i, s := range scores {
+		out[i] = (s - min) / (max - min)
+	}
+	return out
+}
+
+// rankDesc returns document indices ordered by descending score.
+func rankDesc(scores []float32) []int {
+	idxs := make([]int, len(scores))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	sort.Slice(idxs, func(i, j int) bool {
+		return scores[idxs[i]] > scores[idxs[j]]
+	})
+	return idxs
+}
@@ -0,0 +1,98 @@
+package main
+
+import "testing"
+
+// TestRetrieveHybrid_KeywordMatchSurfacesOverSemanticNeighbor verifies a
+// chunk containing the exact query keywords ranks above a chunk whose
+// embedding is closer to the query vector but shares no vocabulary with it.
+func TestRetrieveHybrid_KeywordMatchSurfacesOverSemanticNeighbor(t *testing.T) {
+	vs := newVectorStore()
+
+	// High cosine similarity to the query vector, but no lexical overlap.
+	vs.add(document{
+		text:      "the chicken crossed the road to reach the other side",
+		embedding: []float32{1.0, 0.0, 0.0},
+	})
+	// Low cosine similarity, but contains the exact query keywords
+	// repeated, which should dominate the BM25 component.
+	vs.add(document{
+		text:      "quantum entanglement quantum entanglement explained for beginners",
+		embedding: []float32{0.0, 0.0, 1.0},
+	})
+	// Irrelevant filler document so df/idf aren't trivially degenerate.
+	vs.add(document{
+		text:      "bananas are a good source of potassium",
+		embedding: []float32{0.0, 1.0, 0.0},
+	})
+
+	queryVec := []float32{0.9, 0.1, 0.0} // closest to doc 1, not doc 2
+	// Slightly favor the lexical signal, as a caller would for queries
+	// that look like keyword search rather than open-ended questions.
+	results := vs.retrieveHybrid("quantum entanglement", queryVec, 1, HybridOpts{Alpha: 0.4})
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].text != "quantum entanglement quantum entanglement explained for beginners" {
+		t.Errorf("expected keyword-rich low-cosine doc to rank first, got %q", results[0].text)
+	}
+}
+
+func TestRetrieveHybrid_RRF(t *testing.T) {
+	vs := newVectorStore()
+	vs.add(document{text: "alpha beta gamma", embedding: []float32{1.0, 0.0}})
+	vs.add(document{text: "delta epsilon zeta", embedding: []float32{0.0, 1.0}})
+
+	results := vs.retrieveHybrid("alpha beta", []float32{1.0, 0.0}, 2, HybridOpts{UseRRF: true})
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].text != "alpha beta gamma" {
+		t.Errorf("expected %q first, got %q", "alpha beta gamma", results[0].text)
+	}
+}
+
+func TestRetrieveHybrid_EmptyStore(t *testing.T) {
+	vs := newVectorStore()
+	if got := vs.retrieveHybrid("anything", []float32{1.0}, 3, HybridOpts{}); got != nil {
+		t.Errorf("expected nil results from empty store, got %v", got)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "lowercases and strips punctuation",
+			text:     "Hello, World!",
+			expected: []string{"hello", "world"},
+		},
+		{
+			name:     "drops stopwords",
+			text:     "the quick fox and the lazy dog",
+			expected: []string{"quick", "fox", "lazy", "dog"},
+		},
+		{
+			name:     "empty text",
+			text:     "",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tokenize(tt.text)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("tokenize() = %v, expected %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("tokenize()[%d] = %q, expected %q", i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package ingest
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+)
+
+func init() {
+	Register("eml", EmailLoader{})
+	Register("message/rfc822", EmailLoader{})
+}
+
+// EmailLoader parses RFC 5322 email messages, decoding RFC 2045
+// quoted-printable/base64 bodies and RFC 2047 encoded-word headers, and
+// emits one or more Documents per message with From, Subject, Date and
+// Message-ID metadata. Multipart alternatives prefer text/plain over
+// text/html, falling back to HTML-to-text extraction when only HTML is
+// present. application/pdf attachments are routed through PDFLoader.
+//
+// Each call to Load treats its input as a single message; splitting an
+// mbox archive into individual messages is left to the caller.
+type EmailLoader struct{}
+
+// Load implements Loader.
+func (l EmailLoader) Load(ctx context.Context, src io.Reader, mediatype string) ([]Document, error) {
+	msg, err := mail.ReadMessage(src)
+	if err != nil {
+		return nil, fmt.Errorf("ingest: parse email: %w", err)
+	}
+
+	header := map[string]string{
+		"from":       decodeHeader(msg.Header.Get("From")),
+		"subject":    decodeHeader(msg.Header.Get("Subject")),
+		"date":       msg.Header.Get("Date"),
+		"message_id": msg.Header.Get("Message-Id"),
+	}
+
+	docs, err := l.loadPart(ctx, msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range docs {
+		if docs[i].Metadata == nil {
+			docs[i].Metadata = make(map[string]string, len(header))
+		}
+		for k, v := range header {
+			if _, exists := docs[i].Metadata[k]; !exists {
+				docs[i].Metadata[k] = v
+			}
+		}
+	}
+	return docs, nil
+}
+
+// loadPart decodes a single MIME part (or the top-level message body),
+// recursing into loadMultipart for container types.
+func (l EmailLoader) loadPart(ctx context.Context, contentType, transferEncoding string, body io.Reader) ([]Document, error) {
+	mediatype, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediatype = "text/plain"
+	}
+
+	if strings.HasPrefix(mediatype, "multipart/") {
+		return l.loadMultipart(ctx, mediatype, params, body)
+	}
+
+	decoded, err := decodeTransferEncoding(transferEncoding, body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mediatype {
+	case "application/pdf":
+		return PDFLoader{}.Load(ctx, decoded, "pdf")
+	case "text/html":
+		return HTMLLoader{}.Load(ctx, decoded, "html")
+	default: // text/plain and anything else is treated as plain text
+		data, err := io.ReadAll(decoded)
+		if err != nil {
+			return nil, err
+		}
+		text := strings.TrimSpace(string(data))
+		if text == "" {
+			return nil, nil
+		}
+		return []Document{{Text: text}}, nil
+	}
+}
+
+// loadMultipart walks a multipart body's parts, preferring text/plain over
+// text/html for multipart/alternative, and otherwise concatenating every
+// readable part (e.g. multipart/mixed bodies with attachments).
+func (l EmailLoader) loadMultipart(ctx context.Context, mediatype string, params map[string]string, body io.Reader) ([]Document, error) {
+	boundary := params["boundary"]
+	if boundary == "" {
+		return nil, fmt.Errorf("ingest: multipart message missing boundary")
+	}
+	mr := multipart.NewReader(body, boundary)
+
+	var plainDocs, htmlDocs, otherDocs []Document
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		partContentType := part.Header.Get("Content-Type")
+		partMediatype, _, _ := mime.ParseMediaType(partContentType)
+
+		partDocs, err := l.loadPart(ctx, partContentType, part.Header.Get("Content-Transfer-Encoding"), part)
+		if err != nil {
+			continue // skip unreadable parts rather than failing the whole message
+		}
+
+		switch {
+		case partMediatype == "text/plain" && !isAttachment(part):
+			plainDocs = append(plainDocs, partDocs...)
+		case partMediatype == "text/html" && !isAttachment(part):
+			htmlDocs = append(htmlDocs, partDocs...)
+		default:
+			otherDocs = append(otherDocs, partDocs...) // nested multiparts and attachments
+		}
+	}
+
+	if mediatype == "multipart/alternative" {
+		switch {
+		case len(plainDocs) > 0:
+			return append(plainDocs, otherDocs...), nil
+		case len(htmlDocs) > 0:
+			return append(htmlDocs, otherDocs...), nil
+		}
+	}
+	return append(append(plainDocs, htmlDocs...), otherDocs...), nil
+}
+
+// isAttachment reports whether a multipart part is marked as an
+// attachment rather than inline body content.
+func isAttachment(part *multipart.Part) bool {
+	disposition := strings.ToLower(strings.TrimSpace(part.Header.Get("Content-Disposition")))
+	return strings.HasPrefix(disposition, "attachment")
+}
+
+// decodeTransferEncoding wraps body in a decoder for the RFC 2045
+// Content-Transfer-Encoding it declares, passing it through unchanged for
+// anything else (7bit, 8bit, binary, or absent).
+func decodeTransferEncoding(encoding string, body io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(body), nil
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body), nil
+	default:
+		return body, nil
+	}
+}
+
+// decodeHeader decodes RFC 2047 encoded-words in a header value (e.g.
+// "=?utf-8?q?...?=" or "=?utf-8?b?...?="), falling back to the raw value
+// if it isn't encoded or uses an unsupported charset.
+func decodeHeader(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	decoded, err := (&mime.WordDecoder{}).DecodeHeader(raw)
+	if err != nil {
+		return raw
+	}
+	return decoded
+}
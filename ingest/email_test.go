@@ -0,0 +1,88 @@
+package ingest
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEmailLoader_PlainTextMessage(t *testing.T) {
+	raw := "From: =?utf-8?q?Jos=C3=A9?= <jose@example.com>\r\n" +
+		"Subject: =?utf-8?b?SG9sYSBtdW5kbw==?=\r\n" +
+		"Date: Mon, 27 Jul 2026 10:00:00 +0000\r\n" +
+		"Message-Id: <abc123@example.com>\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"Caf=C3=A9 con leche\r\n"
+
+	docs, err := EmailLoader{}.Load(context.Background(), strings.NewReader(raw), "eml")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("Load() returned %d documents, want 1", len(docs))
+	}
+
+	doc := docs[0]
+	if !strings.Contains(doc.Text, "Café con leche") {
+		t.Errorf("Text = %q, want decoded quoted-printable body", doc.Text)
+	}
+	if doc.Metadata["from"] != "José <jose@example.com>" {
+		t.Errorf("from = %q, want decoded RFC 2047 header", doc.Metadata["from"])
+	}
+	if doc.Metadata["subject"] != "Hola mundo" {
+		t.Errorf("subject = %q, want decoded RFC 2047 header", doc.Metadata["subject"])
+	}
+	if doc.Metadata["message_id"] != "<abc123@example.com>" {
+		t.Errorf("message_id = %q, want %q", doc.Metadata["message_id"], "<abc123@example.com>")
+	}
+}
+
+func TestEmailLoader_PrefersPlainOverHTML(t *testing.T) {
+	raw := "Subject: Alternative parts\r\n" +
+		"Content-Type: multipart/alternative; boundary=\"BOUNDARY\"\r\n" +
+		"\r\n" +
+		"--BOUNDARY\r\n" +
+		"Content-Type: text/html\r\n" +
+		"\r\n" +
+		"<html><body><p>html body</p></body></html>\r\n" +
+		"--BOUNDARY\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"plain body\r\n" +
+		"--BOUNDARY--\r\n"
+
+	docs, err := EmailLoader{}.Load(context.Background(), strings.NewReader(raw), "eml")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("Load() returned %d documents, want 1: %+v", len(docs), docs)
+	}
+	if docs[0].Text != "plain body" {
+		t.Errorf("Text = %q, want the plain-text part to win over html", docs[0].Text)
+	}
+}
+
+func TestEmailLoader_FallsBackToHTML(t *testing.T) {
+	raw := "Subject: HTML only\r\n" +
+		"Content-Type: multipart/alternative; boundary=\"BOUNDARY\"\r\n" +
+		"\r\n" +
+		"--BOUNDARY\r\n" +
+		"Content-Type: text/html\r\n" +
+		"\r\n" +
+		"<html><body><p>only html here</p></body></html>\r\n" +
+		"--BOUNDARY--\r\n"
+
+	docs, err := EmailLoader{}.Load(context.Background(), strings.NewReader(raw), "eml")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("Load() returned %d documents, want 1: %+v", len(docs), docs)
+	}
+	if !strings.Contains(docs[0].Text, "only html here") {
+		t.Errorf("Text = %q, want html-to-text fallback", docs[0].Text)
+	}
+}
@@ -0,0 +1,93 @@
+package ingest
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func init() {
+	Register("html", HTMLLoader{})
+	Register("htm", HTMLLoader{})
+	Register("text/html", HTMLLoader{})
+}
+
+// HTMLLoader walks the HTML DOM and extracts visible text, splitting into
+// one Document per heading section the way MarkdownLoader does, so results
+// retain a heading_path for provenance.
+type HTMLLoader struct{}
+
+// Load implements Loader.
+func (HTMLLoader) Load(ctx context.Context, src io.Reader, mediatype string) ([]Document, error) {
+	root, err := html.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &htmlExtractor{}
+	e.walk(root)
+	e.flush()
+	return e.docs, nil
+}
+
+type htmlExtractor struct {
+	docs        []Document
+	headingPath []string
+	buf         strings.Builder
+}
+
+func (e *htmlExtractor) flush() {
+	text := strings.TrimSpace(e.buf.String())
+	e.buf.Reset()
+	if text == "" {
+		return
+	}
+	e.docs = append(e.docs, Document{
+		Text:     text,
+		Metadata: map[string]string{"heading_path": strings.Join(e.headingPath, " > ")},
+	})
+}
+
+func (e *htmlExtractor) walk(n *html.Node) {
+	switch n.Type {
+	case html.ElementNode:
+		switch n.DataAtom {
+		case atom.Script, atom.Style, atom.Head:
+			return // skip non-visible content
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+			e.flush()
+			level := int(n.DataAtom - atom.H1 + 1)
+			e.headingPath = append(e.headingPath[:minInt(level-1, len(e.headingPath))], textOf(n))
+			return // heading text becomes the path, not body content
+		}
+	case html.TextNode:
+		if text := strings.TrimSpace(n.Data); text != "" {
+			e.buf.WriteString(text)
+			e.buf.WriteString(" ")
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		e.walk(c)
+	}
+}
+
+// textOf concatenates the text content of n's subtree, used to read a
+// heading's title without re-walking it through the main extractor.
+func textOf(n *html.Node) string {
+	var buf strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			buf.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.TrimSpace(buf.String())
+}
@@ -0,0 +1,40 @@
+package ingest
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHTMLLoader_Load(t *testing.T) {
+	input := `<html><head><style>body{color:red}</style></head><body>
+<h1>Intro</h1>
+<p>Some intro text.</p>
+<h2>Details</h2>
+<p>More detail here.</p>
+<script>console.log("should be skipped")</script>
+</body></html>`
+
+	docs, err := HTMLLoader{}.Load(context.Background(), strings.NewReader(input), "html")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	if len(docs) != 2 {
+		t.Fatalf("Load() returned %d documents, want 2: %+v", len(docs), docs)
+	}
+	if docs[0].Metadata["heading_path"] != "Intro" {
+		t.Errorf("doc 0 heading_path = %q, want %q", docs[0].Metadata["heading_path"], "Intro")
+	}
+	if !strings.Contains(docs[0].Text, "Some intro text.") {
+		t.Errorf("doc 0 text missing intro content: %q", docs[0].Text)
+	}
+	if docs[1].Metadata["heading_path"] != "Intro > Details" {
+		t.Errorf("doc 1 heading_path = %q, want %q", docs[1].Metadata["heading_path"], "Intro > Details")
+	}
+	for _, d := range docs {
+		if strings.Contains(d.Text, "should be skipped") {
+			t.Errorf("script content leaked into text: %q", d.Text)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Package ingest turns raw source files into chunks of text for the RAG
+// pipeline, dispatching to a format-specific Loader by file extension or
+// MIME type.
+package ingest
+
+import (
+	"context"
+	"io"
+)
+
+// Document is a unit of content produced by a Loader, along with any
+// structural metadata the loader could recover (page number, heading path,
+// etc.) so retrieval results downstream can carry provenance.
+type Document struct {
+	Text     string
+	Metadata map[string]string
+}
+
+// Loader turns raw source content into one or more Documents.
+type Loader interface {
+	// Load reads src and returns the documents it contains. mediatype is
+	// the normalized extension or MIME type that selected this loader,
+	// passed through for loaders that handle more than one variant.
+	Load(ctx context.Context, src io.Reader, mediatype string) ([]Document, error)
+}
@@ -0,0 +1,114 @@
+package ingest
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+)
+
+func init() {
+	Register("md", MarkdownLoader{})
+	Register("markdown", MarkdownLoader{})
+	Register("text/markdown", MarkdownLoader{})
+}
+
+// MarkdownLoader splits Markdown into one Document per heading section,
+// stripping YAML frontmatter and fenced code blocks' delimiters so chunk
+// boundaries line up with the document's structure rather than an
+// arbitrary character budget.
+type MarkdownLoader struct{}
+
+// Load implements Loader.
+func (MarkdownLoader) Load(ctx context.Context, src io.Reader, mediatype string) ([]Document, error) {
+	lines, err := readLines(src)
+	if err != nil {
+		return nil, err
+	}
+	lines = stripFrontmatter(lines)
+
+	var docs []Document
+	var headingPath []string
+	var buf strings.Builder
+	inFence := false
+
+	flush := func() {
+		text := strings.TrimSpace(buf.String())
+		buf.Reset()
+		if text == "" {
+			return
+		}
+		docs = append(docs, Document{
+			Text:     text,
+			Metadata: map[string]string{"heading_path": strings.Join(headingPath, " > ")},
+		})
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue // drop fence delimiters, keep the code inside
+		}
+		if inFence {
+			buf.WriteString(line)
+			buf.WriteString("\n")
+			continue
+		}
+
+		if level, title, ok := parseHeading(trimmed); ok {
+			flush()
+			headingPath = append(headingPath[:minInt(level-1, len(headingPath))], title)
+			continue
+		}
+
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	flush()
+
+	return docs, nil
+}
+
+// parseHeading recognizes ATX-style headings ("# Title", "## Title", ...).
+func parseHeading(line string) (level int, title string, ok bool) {
+	level = 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(line) || line[level] != ' ' {
+		return 0, "", false
+	}
+	return level, strings.TrimSpace(line[level:]), true
+}
+
+// stripFrontmatter removes a leading "---" ... "---" YAML block.
+func stripFrontmatter(lines []string) []string {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return lines
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return lines[i+1:]
+		}
+	}
+	return lines
+}
+
+func readLines(src io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,60 @@
+package ingest
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownLoader_Load(t *testing.T) {
+	input := `---
+title: Example
+---
+# Intro
+
+Some intro text.
+
+## Details
+
+More detail here.
+
+` + "```go\ncode should not be a fence marker\n```" + `
+`
+
+	docs, err := MarkdownLoader{}.Load(context.Background(), strings.NewReader(input), "md")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	if len(docs) != 2 {
+		t.Fatalf("Load() returned %d documents, want 2: %+v", len(docs), docs)
+	}
+	if docs[0].Metadata["heading_path"] != "Intro" {
+		t.Errorf("doc 0 heading_path = %q, want %q", docs[0].Metadata["heading_path"], "Intro")
+	}
+	if !strings.Contains(docs[0].Text, "Some intro text.") {
+		t.Errorf("doc 0 text missing intro content: %q", docs[0].Text)
+	}
+	if docs[1].Metadata["heading_path"] != "Intro > Details" {
+		t.Errorf("doc 1 heading_path = %q, want %q", docs[1].Metadata["heading_path"], "Intro > Details")
+	}
+	if !strings.Contains(docs[1].Text, "code should not be a fence marker") {
+		t.Errorf("doc 1 text missing fenced content: %q", docs[1].Text)
+	}
+	if strings.Contains(docs[1].Text, "```") {
+		t.Errorf("doc 1 text should not contain fence delimiters: %q", docs[1].Text)
+	}
+}
+
+func TestMarkdownLoader_NoHeadings(t *testing.T) {
+	docs, err := MarkdownLoader{}.Load(context.Background(), strings.NewReader("just a paragraph, no headings"), "md")
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("Load() returned %d documents, want 1", len(docs))
+	}
+	if docs[0].Metadata["heading_path"] != "" {
+		t.Errorf("heading_path = %q, want empty", docs[0].Metadata["heading_path"])
+	}
+}
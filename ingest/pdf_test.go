@@ -0,0 +1,56 @@
+package ingest
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPDFLoader_Load(t *testing.T) {
+	tests := []struct {
+		name        string
+		pdfContent  []byte
+		expectError bool
+	}{
+		{name: "invalid PDF content", pdfContent: []byte("not a pdf"), expectError: true},
+		{name: "empty content", pdfContent: []byte{}, expectError: true},
+		{name: "nil content", pdfContent: nil, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, err := PDFLoader{}.Load(context.Background(), strings.NewReader(string(tt.pdfContent)), "pdf")
+			if tt.expectError && err == nil {
+				t.Errorf("Load() expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Load() unexpected error: %v", err)
+			}
+			if tt.expectError && docs != nil {
+				t.Errorf("Load() expected no documents on error, got %v", docs)
+			}
+		})
+	}
+}
+
+func TestDecodeCaesarCipher(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		shift int
+		want  string
+	}{
+		{name: "uppercase", text: "KHOOR", shift: 3, want: "HELLO"},
+		{name: "lowercase", text: "khoor zruog", shift: 3, want: "hello world"},
+		{name: "non-letters unchanged", text: "abc 123!", shift: 3, want: "xyz 123!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := decodeCaesarCipher(tt.text, tt.shift)
+			if got != tt.want {
+				t.Errorf("decodeCaesarCipher(%q, %d) = %q, want %q", tt.text, tt.shift, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package ingest
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsupported is returned when no Loader is registered for a given
+// extension or MIME type.
+var ErrUnsupported = errors.New("ingest: unsupported media type")
+
+var registry = map[string]Loader{}
+
+// Register associates a Loader with a file extension or MIME type (e.g.
+// "pdf", "md", "text/html"), analogous to Hugo's ResolveMarkup mapping.
+// Registering the same key twice overwrites the earlier loader.
+func Register(key string, loader Loader) {
+	registry[normalizeKey(key)] = loader
+}
+
+// ForPath resolves the Loader registered for path's file extension.
+func ForPath(path string) (loader Loader, mediatype string, err error) {
+	return ForExt(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// ForExt resolves the Loader registered for the given extension or MIME
+// type. It returns ErrUnsupported if none is registered.
+func ForExt(ext string) (loader Loader, mediatype string, err error) {
+	key := normalizeKey(ext)
+	loader, ok := registry[key]
+	if !ok {
+		return nil, key, fmt.Errorf("%w: %q", ErrUnsupported, key)
+	}
+	return loader, key, nil
+}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.TrimPrefix(key, "."))
+}
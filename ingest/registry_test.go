@@ -0,0 +1,53 @@
+package ingest
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestForExt(t *testing.T) {
+	tests := []struct {
+		name    string
+		ext     string
+		wantErr bool
+	}{
+		{name: "pdf", ext: "pdf"},
+		{name: "pdf with leading dot", ext: ".pdf"},
+		{name: "uppercase extension", ext: "PDF"},
+		{name: "markdown", ext: "md"},
+		{name: "html", ext: "html"},
+		{name: "plain text", ext: "txt"},
+		{name: "unsupported", ext: "docx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader, _, err := ForExt(tt.ext)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupported) {
+					t.Fatalf("ForExt(%q) error = %v, want ErrUnsupported", tt.ext, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ForExt(%q) unexpected error: %v", tt.ext, err)
+			}
+			if loader == nil {
+				t.Fatalf("ForExt(%q) returned nil loader", tt.ext)
+			}
+		})
+	}
+}
+
+func TestForPath(t *testing.T) {
+	loader, mediatype, err := ForPath("notes/chapter1.md")
+	if err != nil {
+		t.Fatalf("ForPath() unexpected error: %v", err)
+	}
+	if mediatype != "md" {
+		t.Errorf("ForPath() mediatype = %q, want %q", mediatype, "md")
+	}
+	if _, ok := loader.(MarkdownLoader); !ok {
+		t.Errorf("ForPath() loader = %T, want MarkdownLoader", loader)
+	}
+}
@@ -0,0 +1,31 @@
+package ingest
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+func init() {
+	Register("txt", PlainTextLoader{})
+	Register("text", PlainTextLoader{})
+	Register("text/plain", PlainTextLoader{})
+}
+
+// PlainTextLoader passes raw text through as a single Document with no
+// structural metadata.
+type PlainTextLoader struct{}
+
+// Load implements Loader.
+func (PlainTextLoader) Load(ctx context.Context, src io.Reader, mediatype string) ([]Document, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return nil, nil
+	}
+	return []Document{{Text: text}}, nil
+}
@@ -3,17 +3,25 @@ package main
 import (
 	"bytes"
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
-	"math"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"sort"
 	"strings"
+	"syscall"
 
-	_ "embed" // for embedding the PDF file
+	_ "embed" // for embedding the default PDF file
 
 	"github.com/sashabaranov/go-openai"
-	"rsc.io/pdf"
+	"github.com/theskyinflames/rag-example/api"
+	"github.com/theskyinflames/rag-example/chunk"
+	"github.com/theskyinflames/rag-example/ingest"
+	"github.com/theskyinflames/rag-example/store"
+	"github.com/theskyinflames/rag-example/vecmath"
 )
 
 //go:embed the-egg.pdf
@@ -22,20 +30,41 @@ var pdfContent []byte
 type document struct {
 	text      string
 	embedding []float32
+	metadata  map[string]string
 }
 
 type vectorStore struct {
 	documents []document
+
+	// Lexical index maintained alongside the embeddings so retrieveHybrid
+	// can score documents with BM25 without a second pass over the corpus.
+	termFreqs  []map[string]int // per-document term frequency, parallel to documents
+	docLengths []int            // per-document term count, parallel to documents
+	docFreq    map[string]int   // term -> number of documents containing it
+	totalTerms int              // sum of docLengths, used to derive avgdl
 }
 
 func newVectorStore() *vectorStore {
 	return &vectorStore{
 		documents: make([]document, 0),
+		docFreq:   make(map[string]int),
 	}
 }
 
 func (vs *vectorStore) add(doc document) {
 	vs.documents = append(vs.documents, doc)
+
+	terms := tokenize(doc.text)
+	tf := make(map[string]int, len(terms))
+	for _, t := range terms {
+		tf[t]++
+	}
+	for t := range tf {
+		vs.docFreq[t]++
+	}
+	vs.termFreqs = append(vs.termFreqs, tf)
+	vs.docLengths = append(vs.docLengths, len(terms))
+	vs.totalTerms += len(terms)
 }
 
 func (vs *vectorStore) retrieveTopK(queryVec []float32, k int) []document {
@@ -45,7 +74,7 @@ func (vs *vectorStore) retrieveTopK(queryVec []float32, k int) []document {
 	}
 	var scoredDocs []scored
 	for _, doc := range vs.documents {
-		s := cosineSim(queryVec, doc.embedding)
+		s := vecmath.CosineSim(queryVec, doc.embedding)
 		scoredDocs = append(scoredDocs, scored{Doc: doc, Score: s})
 	}
 	sort.Slice(scoredDocs, func(i, j int) bool {
@@ -60,6 +89,14 @@ func (vs *vectorStore) retrieveTopK(queryVec []float32, k int) []document {
 }
 
 func main() {
+	input := flag.String("input", "", "file or directory to ingest (defaults to the embedded the-egg.pdf)")
+	storePath := flag.String("store", "", "optional path to a persistent vector store; reused across runs instead of re-embedding")
+	serveAddr := flag.String("serve", "", "if set, run an HTTP/2 API server on this address (e.g. :8080) instead of the one-shot CLI flow")
+	tlsCert := flag.String("tls-cert", "", "TLS certificate for -serve; omit together with -tls-key to serve h2c")
+	tlsKey := flag.String("tls-key", "", "TLS private key for -serve; omit together with -tls-cert to serve h2c")
+	hybrid := flag.Bool("hybrid", false, "retrieve with hybrid BM25+cosine scoring instead of plain cosine (ignored when -store is set, since the persistent store keeps no lexical index)")
+	flag.Parse()
+
 	ctx := context.Background()
 	apiKey := os.Getenv("OPENAI_API_KEY")
 	if apiKey == "" {
@@ -69,30 +106,78 @@ func main() {
 	// Configure client for OpenAI API
 	client := openai.NewClient(apiKey)
 
-	// Initialize vector store
-	vectorStore := newVectorStore()
+	if *serveAddr != "" {
+		srv := api.NewServer(client)
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
 
-	// Step 1: Extract text from PDF
-	text, err := extractTextFromPDF(pdfContent)
-	if err != nil {
-		log.Fatal(err)
+		log.Printf("listening on %s", *serveAddr)
+		if err := api.Serve(ctx, *serveAddr, srv.Handler(), *tlsCert, *tlsKey); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	var persisted *store.Store
+	if *storePath != "" {
+		s, err := store.Open(*storePath)
+		if err != nil {
+			if errors.Is(err, store.ErrCorrupted) {
+				log.Fatalf("vector store %s is corrupted, delete it to rebuild from source: %v", *storePath, err)
+			}
+			log.Fatal(err)
+		}
+		defer s.Close()
+		persisted = s
 	}
-	fmt.Printf("Extracted text from PDF: %s\n", text)
-
-	// Step 2: Chunk text
-	chunks := chunkText(text, 300)
-	fmt.Printf("Created %d chunks\n", len(chunks))
-
-	// Step 3: Embed and store
-	i := 0
-	for _, chunk := range chunks {
-		i++
-		fmt.Printf("Processing chunk %d/%d\n", i, len(chunks))
-		vec, err := embedText(ctx, client, chunk)
+
+	// Initialize the in-memory vector store used for hybrid (BM25 + cosine)
+	// retrieval; the persistent store, when enabled, only ever does plain
+	// cosine retrieval.
+	vectorStore := newVectorStore()
+
+	if persisted == nil || persisted.Len() == 0 {
+		// Step 1: Load source documents
+		docs, err := loadDocuments(*input)
 		if err != nil {
 			log.Fatal(err)
 		}
-		vectorStore.add(document{text: chunk, embedding: vec})
+		fmt.Printf("Loaded %d source documents\n", len(docs))
+
+		// Step 2: Chunk each source document
+		chunker := chunk.NewSentenceChunker(chunk.DefaultTargetTokens, chunk.DefaultOverlap)
+		var chunks []document
+		for _, doc := range docs {
+			for _, text := range chunker.Chunk(doc.Text) {
+				chunks = append(chunks, document{text: text, metadata: doc.Metadata})
+			}
+		}
+		fmt.Printf("Created %d chunks\n", len(chunks))
+
+		// Step 3: Embed and store
+		for i, ch := range chunks {
+			fmt.Printf("Processing chunk %d/%d\n", i+1, len(chunks))
+			vec, err := embedText(ctx, client, ch.text)
+			if err != nil {
+				log.Fatal(err)
+			}
+			ch.embedding = vec
+			vectorStore.add(ch)
+
+			if persisted != nil {
+				if err := persisted.Add(store.Document{Text: ch.text, Embedding: vec, Metadata: ch.metadata}); err != nil {
+					log.Fatal(err)
+				}
+			}
+		}
+
+		if persisted != nil {
+			if err := persisted.Flush(); err != nil {
+				log.Fatal(err)
+			}
+		}
+	} else {
+		fmt.Printf("Reusing %d documents from persistent store %s\n", persisted.Len(), *storePath)
 	}
 
 	// Step 4: Get user query
@@ -100,12 +185,25 @@ func main() {
 	qVec, _ := embedText(ctx, client, query)
 
 	// Step 5: Retrieve top-k chunks
-	retrieved := vectorStore.retrieveTopK(qVec, 3)
+	var retrieved []document
+	switch {
+	case persisted != nil:
+		for _, doc := range persisted.RetrieveTopK(qVec, 3) {
+			retrieved = append(retrieved, document{text: doc.Text, embedding: doc.Embedding, metadata: doc.Metadata})
+		}
+	case *hybrid:
+		retrieved = vectorStore.retrieveHybrid(query, qVec, 3, HybridOpts{})
+	default:
+		retrieved = vectorStore.retrieveTopK(qVec, 3)
+	}
 
 	// Debug: Print retrieved chunks
 	fmt.Println("=== RETRIEVED CHUNKS ===")
 	for i, doc := range retrieved {
 		fmt.Printf("Chunk %d: %s\n", i+1, doc.text)
+		if len(doc.metadata) > 0 {
+			fmt.Printf("  metadata: %v\n", doc.metadata)
+		}
 		fmt.Println("---")
 	}
 	fmt.Println("=== END CHUNKS ===")
@@ -134,101 +232,66 @@ func main() {
 }
 
 // --- UTILITIES ---
-// extractTextFromPDF extracts text from a PDF file with better error handling and text cleaning.
-func extractTextFromPDF(pdfContent []byte) (string, error) {
-	r, err := pdf.NewReader(bytes.NewReader(pdfContent), int64(len(pdfContent)))
-	if err != nil {
-		return "", err
-	}
-	var buf bytes.Buffer
-	for i := 1; i <= r.NumPage(); i++ {
-		p := r.Page(i)
-		if p.V.IsNull() {
-			continue
-		}
-		content := p.Content()
-		for _, text := range content.Text {
-			// Clean and filter text
-			cleanText := strings.TrimSpace(text.S)
-			if len(cleanText) > 0 {
-				// Replace common problematic characters
-				cleanText = strings.ReplaceAll(cleanText, "\x00", "")
-				cleanText = strings.ReplaceAll(cleanText, "\ufffd", "") // replacement character
-
-				// Decode Caesar cipher (shift back by 3)
-				cleanText = decodeCaesarCipher(cleanText, 3)
-
-				buf.WriteString(cleanText + " ")
-			}
-		}
-		buf.WriteString("\n") // Add line break between pages
-	}
-
-	// Final cleaning
-	result := buf.String()
-	result = strings.ReplaceAll(result, "\r\n", "\n")
-	result = strings.ReplaceAll(result, "\r", "\n")
 
-	return result, nil
-}
-
-// decodeCaesarCipher decodes text that has been encoded with a Caesar cipher
-func decodeCaesarCipher(text string, shift int) string {
-	var result strings.Builder
-
-	for _, char := range text {
-		if char >= 'A' && char <= 'Z' {
-			// Uppercase letters
-			decoded := ((int(char-'A') - shift + 26) % 26) + int('A')
-			result.WriteRune(rune(decoded))
-		} else if char >= 'a' && char <= 'z' {
-			// Lowercase letters
-			decoded := ((int(char-'a') - shift + 26) % 26) + int('a')
-			result.WriteRune(rune(decoded))
-		} else {
-			// Keep other characters unchanged (numbers, spaces, punctuation)
-			result.WriteRune(char)
+// loadDocuments dispatches to the registered ingest.Loader for each file
+// under path by extension. When path is empty, it falls back to the
+// embedded the-egg.pdf so the example keeps working out of the box. When
+// path is a directory, it is walked recursively; unreadable or unsupported
+// files are skipped with a warning rather than aborting the whole run.
+func loadDocuments(path string) ([]ingest.Document, error) {
+	if path == "" {
+		loader, mediatype, err := ingest.ForExt("pdf")
+		if err != nil {
+			return nil, err
 		}
+		return loader.Load(context.Background(), bytes.NewReader(pdfContent), mediatype)
 	}
 
-	return result.String()
-}
-
-// chunkText splits text into chunks of approximately maxLen characters.
-func chunkText(text string, maxLen int) []string {
-	if maxLen <= 0 {
-		// For non-positive maxLen, return each word as separate chunk
-		return strings.Fields(text)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
 	}
 
-	words := strings.Fields(text)
-	if len(words) == 0 {
-		return []string{}
+	var files []string
+	if info.IsDir() {
+		err = filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !fi.IsDir() {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		files = []string{path}
 	}
 
-	var chunks []string
-	var buf []string
-
-	for _, word := range words {
-		// Try adding this word to current buffer
-		testBuf := append(buf, word)
-		testChunk := strings.Join(testBuf, " ")
-
-		// If this would exceed maxLen and we have words in buffer, create chunk
-		if len(testChunk) > maxLen && len(buf) > 0 {
-			chunks = append(chunks, strings.Join(buf, " "))
-			buf = []string{word} // Start new chunk with current word
-		} else {
-			buf = append(buf, word)
+	var docs []ingest.Document
+	for _, f := range files {
+		loader, mediatype, err := ingest.ForPath(f)
+		if err != nil {
+			fmt.Printf("skipping %s: %v\n", f, err)
+			continue
 		}
-	}
 
-	// Add remaining words as final chunk
-	if len(buf) > 0 {
-		chunks = append(chunks, strings.Join(buf, " "))
+		file, err := os.Open(f)
+		if err != nil {
+			fmt.Printf("skipping %s: %v\n", f, err)
+			continue
+		}
+		fileDocs, err := loader.Load(context.Background(), file, mediatype)
+		file.Close()
+		if err != nil {
+			fmt.Printf("skipping %s: %v\n", f, err)
+			continue
+		}
+		docs = append(docs, fileDocs...)
 	}
-
-	return chunks
+	return docs, nil
 }
 
 // embedText uses the OpenAI API to embed text and returns the embedding vector.
@@ -242,18 +305,3 @@ func embedText(ctx context.Context, client *openai.Client, input string) ([]floa
 	}
 	return resp.Data[0].Embedding, nil
 }
-
-// cosineSim calculates the cosine similarity between two vectors.
-func cosineSim(a, b []float32) float32 {
-	var dot, normA, normB float32
-	for i := range a {
-		dot += a[i] * b[i]
-		normA += a[i] * a[i]
-		normB += b[i] * b[i]
-	}
-	return dot / (sqrt(normA) * sqrt(normB))
-}
-
-func sqrt(x float32) float32 {
-	return float32(math.Sqrt(float64(x)))
-}
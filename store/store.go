@@ -0,0 +1,403 @@
+// Package store provides an optional file-backed vector store, so
+// embeddings don't have to be recomputed on every run and the same index
+// can be reused across processes. The on-disk layout is a single
+// append-only file of fixed-shape blocks (varint textLen, textBytes,
+// uint32 dim, dim little-endian float32 values, varint metadata pair
+// count, each pair as varint-prefixed key/value strings, uint32 crc32),
+// modeled on the block-plus-footer-index layout of an LSM/SSTable file.
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/theskyinflames/rag-example/vecmath"
+)
+
+// ErrCorrupted is returned by Open when a stored block fails its CRC
+// check, mirroring the IsCorrupted pattern used by streaming decoders
+// elsewhere: callers can catch it with errors.Is and decide whether to
+// rebuild the index from source documents rather than trust a partially
+// written file.
+var ErrCorrupted = errors.New("store: corrupted block")
+
+// ErrLocked is returned by Open when another process already holds the
+// store's lock file.
+var ErrLocked = errors.New("store: already locked by another process")
+
+// Document is a single persisted record.
+type Document struct {
+	Text      string
+	Embedding []float32
+
+	// Metadata carries loader-supplied provenance (page number, heading
+	// path, ...) through to retrieval results.
+	Metadata map[string]string
+}
+
+// Store is a file-backed vector store. It is safe for concurrent use
+// within a single process; a lock file (path+".lock") keeps a second
+// process from opening the same store at the same time.
+type Store struct {
+	mu sync.Mutex
+
+	file     *os.File
+	lock     *os.File
+	lockPath string
+
+	docs    []Document
+	offsets []int64
+	dataEnd int64 // offset right after the last written block, where the footer starts
+}
+
+// Open opens or creates the store at path. If the file already contains
+// data, every block is verified against its stored CRC; a mismatch
+// surfaces as ErrCorrupted rather than returning a partial or wrong
+// result.
+func Open(path string) (*Store, error) {
+	lockPath := path + ".lock"
+	lock, err := acquireLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		lock.Close()
+		os.Remove(lockPath)
+		return nil, err
+	}
+
+	s := &Store{file: file, lock: lock, lockPath: lockPath}
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	if size == 0 {
+		return s, nil
+	}
+
+	offsets, footerStart, err := readFooterTable(file, size)
+	if err != nil {
+		// No usable footer (e.g. the process crashed before Flush): fall
+		// back to a full sequential scan, verifying every block found.
+		offsets, footerStart, err = scanBlockOffsets(file, size)
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+
+	docs := make([]Document, 0, len(offsets))
+	for _, off := range offsets {
+		doc, _, err := readBlockAt(file, off, size)
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("%w: %v", ErrCorrupted, err)
+		}
+		docs = append(docs, doc)
+	}
+
+	if err := file.Truncate(footerStart); err != nil {
+		s.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(footerStart, io.SeekStart); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	s.docs = docs
+	s.offsets = offsets
+	s.dataEnd = footerStart
+	return s, nil
+}
+
+// Add appends doc as a new block. The write is not guaranteed durable
+// until Flush is called.
+func (s *Store) Add(doc Document) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	block := encodeBlock(doc)
+	if _, err := s.file.WriteAt(block, s.dataEnd); err != nil {
+		return err
+	}
+
+	s.offsets = append(s.offsets, s.dataEnd)
+	s.docs = append(s.docs, doc)
+	s.dataEnd += int64(len(block))
+	return nil
+}
+
+// Flush writes the footer index (doc offsets) and syncs the file to disk.
+func (s *Store) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var footer bytes.Buffer
+	if err := binary.Write(&footer, binary.LittleEndian, uint32(len(s.offsets))); err != nil {
+		return err
+	}
+	for _, off := range s.offsets {
+		if err := binary.Write(&footer, binary.LittleEndian, off); err != nil {
+			return err
+		}
+	}
+	if err := binary.Write(&footer, binary.LittleEndian, s.dataEnd); err != nil {
+		return err
+	}
+
+	if _, err := s.file.WriteAt(footer.Bytes(), s.dataEnd); err != nil {
+		return err
+	}
+	if err := s.file.Truncate(s.dataEnd + int64(footer.Len())); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Len returns the number of documents currently held by the store.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.docs)
+}
+
+// RetrieveTopK returns the k documents with the highest cosine similarity
+// to vec. Scoring is a flat linear scan; an ANN index (HNSW/IVF) is left
+// as future work for corpora where that becomes the bottleneck.
+func (s *Store) RetrieveTopK(vec []float32, k int) []Document {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type scored struct {
+		doc   Document
+		score float32
+	}
+	scoredDocs := make([]scored, len(s.docs))
+	for i, doc := range s.docs {
+		scoredDocs[i] = scored{doc: doc, score: vecmath.CosineSim(vec, doc.Embedding)}
+	}
+	sort.Slice(scoredDocs, func(i, j int) bool {
+		return scoredDocs[i].score > scoredDocs[j].score
+	})
+
+	var top []Document
+	for i := 0; i < k && i < len(scoredDocs); i++ {
+		top = append(top, scoredDocs[i].doc)
+	}
+	return top
+}
+
+// Close releases the store's file handle and lock file. It does not
+// implicitly Flush.
+func (s *Store) Close() error {
+	err := s.file.Close()
+	s.lock.Close()
+	os.Remove(s.lockPath)
+	return err
+}
+
+func acquireLock(lockPath string) (*os.File, error) {
+	lock, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, ErrLocked
+		}
+		return nil, err
+	}
+	return lock, nil
+}
+
+// encodeBlock serializes doc as (varint textLen, textBytes, uint32 dim,
+// dim*float32, varint metadata pair count, each pair as varint-prefixed
+// key/value strings, uint32 crc32), where the CRC covers every byte
+// preceding it.
+func encodeBlock(doc Document) []byte {
+	var buf bytes.Buffer
+
+	writeString := func(s string) {
+		var vbuf [binary.MaxVarintLen64]byte
+		n := binary.PutUvarint(vbuf[:], uint64(len(s)))
+		buf.Write(vbuf[:n])
+		buf.WriteString(s)
+	}
+
+	writeString(doc.Text)
+
+	binary.Write(&buf, binary.LittleEndian, uint32(len(doc.Embedding)))
+	for _, f := range doc.Embedding {
+		binary.Write(&buf, binary.LittleEndian, math.Float32bits(f))
+	}
+
+	// Metadata keys are sorted before writing so re-encoding a decoded
+	// Document for CRC verification reproduces the exact same bytes
+	// regardless of Go's randomized map iteration order.
+	keys := make([]string, 0, len(doc.Metadata))
+	for k := range doc.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var vbuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(vbuf[:], uint64(len(keys)))
+	buf.Write(vbuf[:n])
+	for _, k := range keys {
+		writeString(k)
+		writeString(doc.Metadata[k])
+	}
+
+	crc := crc32.ChecksumIEEE(buf.Bytes())
+	binary.Write(&buf, binary.LittleEndian, crc)
+	return buf.Bytes()
+}
+
+// readString reads a varint-prefixed string, the same shape writeString
+// produces in encodeBlock.
+func readString(r *bufio.Reader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// readBlock decodes one block from r, verifying its CRC, and returns the
+// document plus the number of bytes the block occupied on disk.
+func readBlock(r *bufio.Reader) (Document, int, error) {
+	textLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Document{}, 0, err
+	}
+
+	text := make([]byte, textLen)
+	if _, err := io.ReadFull(r, text); err != nil {
+		return Document{}, 0, err
+	}
+
+	var dim uint32
+	if err := binary.Read(r, binary.LittleEndian, &dim); err != nil {
+		return Document{}, 0, err
+	}
+
+	embedding := make([]float32, dim)
+	for i := range embedding {
+		var bits uint32
+		if err := binary.Read(r, binary.LittleEndian, &bits); err != nil {
+			return Document{}, 0, err
+		}
+		embedding[i] = math.Float32frombits(bits)
+	}
+
+	pairCount, err := binary.ReadUvarint(r)
+	if err != nil {
+		return Document{}, 0, err
+	}
+	var metadata map[string]string
+	if pairCount > 0 {
+		metadata = make(map[string]string, pairCount)
+		for i := uint64(0); i < pairCount; i++ {
+			k, err := readString(r)
+			if err != nil {
+				return Document{}, 0, err
+			}
+			v, err := readString(r)
+			if err != nil {
+				return Document{}, 0, err
+			}
+			metadata[k] = v
+		}
+	}
+
+	var wantCRC uint32
+	if err := binary.Read(r, binary.LittleEndian, &wantCRC); err != nil {
+		return Document{}, 0, err
+	}
+
+	doc := Document{Text: string(text), Embedding: embedding, Metadata: metadata}
+	encoded := encodeBlock(doc)
+	gotCRC := binary.LittleEndian.Uint32(encoded[len(encoded)-4:])
+	if gotCRC != wantCRC {
+		return Document{}, 0, fmt.Errorf("crc mismatch")
+	}
+	return doc, len(encoded), nil
+}
+
+// readBlockAt decodes the block starting at offset.
+func readBlockAt(file *os.File, offset, size int64) (Document, int, error) {
+	r := bufio.NewReader(io.NewSectionReader(file, offset, size-offset))
+	return readBlock(r)
+}
+
+// scanBlockOffsets reads every block sequentially from the start of the
+// file, verifying CRCs as it goes, and returns each block's starting
+// offset plus the offset just past the last block (where the footer, if
+// any, would begin).
+func scanBlockOffsets(file *os.File, size int64) ([]int64, int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+	r := bufio.NewReader(io.LimitReader(file, size))
+
+	var offsets []int64
+	var offset int64
+	for offset < size {
+		_, n, err := readBlock(r)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", ErrCorrupted, err)
+		}
+		offsets = append(offsets, offset)
+		offset += int64(n)
+	}
+	return offsets, offset, nil
+}
+
+// readFooterTable reads the trailing doc-ID -> offset index written by
+// Flush: a uint32 count, that many int64 offsets, and an int64 trailer
+// naming where the footer begins. It fails if the file is too short or
+// the trailer points outside the file, which Open treats as "no usable
+// footer" and falls back to scanBlockOffsets.
+func readFooterTable(file *os.File, size int64) ([]int64, int64, error) {
+	if size < 8 {
+		return nil, 0, fmt.Errorf("store: file too small for a footer")
+	}
+
+	var trailer [8]byte
+	if _, err := file.ReadAt(trailer[:], size-8); err != nil {
+		return nil, 0, err
+	}
+	footerStart := int64(binary.LittleEndian.Uint64(trailer[:]))
+	if footerStart < 0 || footerStart > size-8 {
+		return nil, 0, fmt.Errorf("store: footer trailer out of range")
+	}
+
+	footer := io.NewSectionReader(file, footerStart, size-8-footerStart)
+	var count uint32
+	if err := binary.Read(footer, binary.LittleEndian, &count); err != nil {
+		return nil, 0, err
+	}
+
+	offsets := make([]int64, count)
+	for i := range offsets {
+		if err := binary.Read(footer, binary.LittleEndian, &offsets[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+	return offsets, footerStart, nil
+}
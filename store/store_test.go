@@ -0,0 +1,152 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_AddFlushReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vectors.db")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+
+	docs := []Document{
+		{Text: "doc one", Embedding: []float32{1.0, 0.0, 0.0}},
+		{Text: "doc two", Embedding: []float32{0.0, 1.0, 0.0}},
+	}
+	for _, d := range docs {
+		if err := s.Add(d); err != nil {
+			t.Fatalf("Add() unexpected error: %v", err)
+		}
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Flush() unexpected error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() after reopen unexpected error: %v", err)
+	}
+	defer reopened.Close()
+
+	top := reopened.RetrieveTopK([]float32{1.0, 0.0, 0.0}, 1)
+	if len(top) != 1 || top[0].Text != "doc one" {
+		t.Errorf("RetrieveTopK() after reopen = %+v, want doc one first", top)
+	}
+}
+
+func TestStore_MetadataSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vectors.db")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+
+	doc := Document{
+		Text:      "doc one",
+		Embedding: []float32{1.0, 0.0, 0.0},
+		Metadata:  map[string]string{"page": "3", "heading": "Intro > Background"},
+	}
+	if err := s.Add(doc); err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Flush() unexpected error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() after reopen unexpected error: %v", err)
+	}
+	defer reopened.Close()
+
+	top := reopened.RetrieveTopK([]float32{1.0, 0.0, 0.0}, 1)
+	if len(top) != 1 {
+		t.Fatalf("RetrieveTopK() = %d results, want 1", len(top))
+	}
+	if top[0].Metadata["page"] != "3" || top[0].Metadata["heading"] != "Intro > Background" {
+		t.Errorf("Metadata after reopen = %+v, want page=3 heading=%q", top[0].Metadata, "Intro > Background")
+	}
+}
+
+func TestStore_RetrieveTopK_Order(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(filepath.Join(dir, "vectors.db"))
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+	defer s.Close()
+
+	s.Add(Document{Text: "perfect match", Embedding: []float32{1.0, 0.0}})
+	s.Add(Document{Text: "orthogonal", Embedding: []float32{0.0, 1.0}})
+	s.Add(Document{Text: "partial match", Embedding: []float32{0.5, 0.5}})
+
+	top := s.RetrieveTopK([]float32{1.0, 0.0}, 3)
+	expected := []string{"perfect match", "partial match", "orthogonal"}
+	for i, doc := range top {
+		if doc.Text != expected[i] {
+			t.Errorf("result %d = %q, want %q", i, doc.Text, expected[i])
+		}
+	}
+}
+
+func TestStore_OpenDetectsCorruption(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vectors.db")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+	if err := s.Add(Document{Text: "doc one", Embedding: []float32{1.0, 0.0}}); err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Flush() unexpected error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+
+	// Flip a byte inside the block to corrupt it without changing its length.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() unexpected error: %v", err)
+	}
+	data[2] ^= 0xFF
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+
+	if _, err := Open(path); err == nil {
+		t.Error("Open() expected ErrCorrupted for a flipped byte, got nil error")
+	}
+}
+
+func TestStore_OpenLocksAgainstConcurrentWriters(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vectors.db")
+
+	first, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() unexpected error: %v", err)
+	}
+	defer first.Close()
+
+	if _, err := Open(path); err != ErrLocked {
+		t.Errorf("second Open() error = %v, want ErrLocked", err)
+	}
+}
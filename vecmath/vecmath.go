@@ -0,0 +1,23 @@
+// Package vecmath holds the small numeric routines shared by every
+// retrieval store (the in-memory vectorStore, the persistent store, and
+// the API server's corpus) so cosine similarity has one definition
+// instead of being copied into each package.
+package vecmath
+
+import "math"
+
+// CosineSim calculates the cosine similarity between two equal-length
+// vectors.
+func CosineSim(a, b []float32) float32 {
+	var dot, normA, normB float32
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	return dot / (sqrt32(normA) * sqrt32(normB))
+}
+
+func sqrt32(x float32) float32 {
+	return float32(math.Sqrt(float64(x)))
+}